@@ -0,0 +1,60 @@
+// Package backoff implements the gRPC-style exponential backoff strategy
+// (see grpc's DefaultBackoffConfig) used to pace modem login and scrape
+// retries, plus a small circuit breaker built on top of it so the exporter
+// stops hammering a modem that is rebooting or otherwise unreachable.
+package backoff
+
+import (
+    "math/rand"
+    "time"
+)
+
+// Strategy computes the delay before the next retry, given how many
+// consecutive failures have occurred so far.  The delay grows
+// exponentially from BaseDelay by Factor on each retry, capped at MaxDelay,
+// then jittered by +/-Jitter to spread reconnect storms across many
+// exporters hitting the same modem firmware bug at once.
+type Strategy struct {
+    BaseDelay time.Duration
+    Factor    float64
+    Jitter    float64
+    MaxDelay  time.Duration
+}
+
+// Default mirrors grpc's DefaultBackoffConfig, tuned for a modem's web UI
+// rather than a grpc channel.
+var Default = Strategy{
+    BaseDelay: time.Second,
+    Factor:    1.6,
+    Jitter:    0.2,
+    MaxDelay:  120 * time.Second,
+}
+
+// Backoff returns the delay to wait before retrying after `retries`
+// consecutive failures (retries == 0 meaning the first retry after the
+// initial failure).
+func (s Strategy) Backoff(retries int) time.Duration {
+    if retries <= 0 {
+        return s.jittered(float64(s.BaseDelay))
+    }
+    backoff, max := float64(s.BaseDelay), float64(s.MaxDelay)
+    for backoff < max && retries > 0 {
+        backoff *= s.Factor
+        retries--
+    }
+    if backoff > max {
+        backoff = max
+    }
+    return s.jittered(backoff)
+}
+
+func (s Strategy) jittered(backoff float64) time.Duration {
+    delta := s.Jitter * backoff
+    min := backoff - delta
+    max := backoff + delta
+    jittered := min + (max-min)*rand.Float64()
+    if jittered < 0 {
+        jittered = 0
+    }
+    return time.Duration(jittered)
+}
@@ -0,0 +1,103 @@
+package backoff
+
+import (
+    "sync"
+    "time"
+)
+
+// State is the state of a CircuitBreaker, exposed as the
+// sb8200_modem_circuit_state metric value.
+type State int
+
+const (
+    // Closed means calls are attempted normally.
+    Closed State = iota
+    // Open means recent calls have failed repeatedly; calls are refused
+    // until the backoff-computed cooldown elapses.
+    Open
+    // HalfOpen means the cooldown has elapsed and a single probe call is
+    // being allowed through to test whether the modem has recovered.
+    HalfOpen
+)
+
+// String returns the Prometheus-friendly label for a state.
+func (s State) String() string {
+    switch s {
+    case Closed:
+        return "closed"
+    case Open:
+        return "open"
+    case HalfOpen:
+        return "half_open"
+    default:
+        return "unknown"
+    }
+}
+
+// CircuitBreaker tracks consecutive failures of some external call (here,
+// modem login) and refuses further attempts once FailureThreshold is
+// reached, so callers stop retrying at full rate.  The cooldown before a
+// retry is allowed again grows according to Strategy, keyed on the number
+// of consecutive failures, and resets as soon as a call succeeds.
+type CircuitBreaker struct {
+    Strategy         Strategy
+    FailureThreshold int
+
+    mu       sync.Mutex
+    state    State
+    failures int
+    openedAt time.Time
+}
+
+// NewCircuitBreaker returns a closed CircuitBreaker using strategy to pace
+// its cooldowns once it opens after failureThreshold consecutive failures.
+func NewCircuitBreaker(strategy Strategy, failureThreshold int) *CircuitBreaker {
+    return &CircuitBreaker{Strategy: strategy, FailureThreshold: failureThreshold}
+}
+
+// Allow reports whether a call should be attempted right now.  It also
+// performs the Open -> HalfOpen transition once the cooldown has elapsed.
+func (b *CircuitBreaker) Allow() bool {
+    b.mu.Lock()
+    defer b.mu.Unlock()
+    switch b.state {
+    case Open:
+        if time.Since(b.openedAt) < b.Strategy.Backoff(b.failures-b.FailureThreshold) {
+            return false
+        }
+        b.state = HalfOpen
+        return true
+    case HalfOpen:
+        // The single trial call is already in flight.
+        return false
+    default:
+        return true
+    }
+}
+
+// OnSuccess resets the failure counter and closes the breaker.
+func (b *CircuitBreaker) OnSuccess() {
+    b.mu.Lock()
+    defer b.mu.Unlock()
+    b.failures = 0
+    b.state = Closed
+}
+
+// OnFailure records a failed call, opening (or re-opening, from HalfOpen)
+// the breaker once FailureThreshold consecutive failures have occurred.
+func (b *CircuitBreaker) OnFailure() {
+    b.mu.Lock()
+    defer b.mu.Unlock()
+    b.failures++
+    if b.state == HalfOpen || b.failures >= b.FailureThreshold {
+        b.state = Open
+        b.openedAt = time.Now()
+    }
+}
+
+// State returns the breaker's current state.
+func (b *CircuitBreaker) State() State {
+    b.mu.Lock()
+    defer b.mu.Unlock()
+    return b.state
+}
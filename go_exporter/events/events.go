@@ -0,0 +1,209 @@
+// Package events implements a small push-oriented log subsystem for the
+// modem's event log, inspired by CoreDNS's dnstap streaming plugin: a
+// bounded ring buffer backs point-in-time reads (the plain-text /logs
+// endpoint), while per-subscriber channels fan new entries out to the
+// long-poll and Server-Sent-Events streaming endpoints as they're
+// observed, instead of making every downstream log pipeline poll and
+// dedupe the full log on its own.
+package events
+
+import (
+    "log"
+    "strconv"
+    "strings"
+    "sync"
+    "time"
+
+    "github.com/prometheus/client_golang/prometheus"
+)
+
+// Event is one modem event log entry, in the stable JSON schema served by
+// /logs/stream and /logs/sse.
+type Event struct {
+    ID string `json:"id"`
+    // Time is the modem's own reported timestamp for this entry, verbatim
+    // (its format varies by firmware and isn't parsed).
+    Time string `json:"time"`
+    // TimestampNS is Time parsed to Unix nanoseconds where possible,
+    // falling back to the time this entry was first observed by the
+    // exporter if Time can't be parsed, so the field is always usable for
+    // ordering even when the modem's format is unrecognised.
+    TimestampNS int64  `json:"timestamp_ns"`
+    Level       string `json:"level"`
+    Desc        string `json:"desc"`
+    ModemHost   string `json:"modem_host"`
+    Seq         uint64 `json:"seq"`
+}
+
+// RawEntry is one entry as parsed from the modem's fun=20 XML response,
+// before dedup and enrichment into an Event.
+type RawEntry struct {
+    ID    string
+    Time  string
+    Level string
+    Desc  string
+}
+
+// eventTimeLayouts are the modem event log time formats observed in the
+// wild, tried in order.  None of them are guaranteed by any spec, so a
+// failure to match any of them is expected and handled, not an error.
+var eventTimeLayouts = []string{
+    "01/02/2006 15:04:05",
+    "2006-01-02 15:04:05",
+    time.RFC1123,
+}
+
+// parseEventTime attempts to parse a modem-reported event time, returning
+// ok=false if none of eventTimeLayouts match.
+func parseEventTime(raw string) (ns int64, ok bool) {
+    for _, layout := range eventTimeLayouts {
+        if t, err := time.Parse(layout, raw); err == nil {
+            return t.UnixNano(), true
+        }
+    }
+    return 0, false
+}
+
+// Bus dedupes modem event log snapshots against the highest event ID seen
+// so far, retains the most recent entries in a bounded ring, and fans newly
+// observed entries out to subscribers.
+type Bus struct {
+    mu       sync.Mutex
+    capacity int
+    ring     []Event
+    seq      uint64
+    highID   int64
+    subs     map[chan Event]struct{}
+
+    // NewTotal counts newly observed entries by severity level, so alerts
+    // can fire on the rate of new critical events rather than the
+    // cumulative sb8200_eventlog_count gauge.
+    NewTotal *prometheus.CounterVec
+}
+
+// NewBus returns a Bus whose ring retains up to capacity entries.
+func NewBus(capacity int) *Bus {
+    return &Bus{
+        capacity: capacity,
+        subs:     make(map[chan Event]struct{}),
+        NewTotal: prometheus.NewCounterVec(prometheus.CounterOpts{
+            Name: "sb8200_eventlog_new_total",
+            Help: "Count of newly observed modem event log entries by severity level",
+        }, []string{"level"}),
+    }
+}
+
+// SetCapacity changes how many entries the ring retains, trimming
+// immediately if it is shrinking.
+func (b *Bus) SetCapacity(capacity int) {
+    b.mu.Lock()
+    defer b.mu.Unlock()
+    b.capacity = capacity
+    if len(b.ring) > b.capacity {
+        b.ring = b.ring[len(b.ring)-b.capacity:]
+    }
+}
+
+// Ingest takes a modem event log snapshot, keeps only the entries whose ID
+// is higher than the highest one seen on a prior call, records them in the
+// ring, bumps NewTotal and fans them out to subscribers.  Entries whose ID
+// can't be parsed as an integer are skipped, since they can't be compared
+// against the high-water mark.  It returns the newly observed entries.
+//
+// If the modem reboots, its event log ID counter is liable to restart from
+// a small value, which would otherwise look like every subsequent entry is
+// older than anything already seen and get silently dropped forever.  If
+// the batch's highest ID is lower than the high-water mark, that's taken as
+// a counter reset and the mark is cleared before entries are compared.
+func (b *Bus) Ingest(host string, raw []RawEntry) []Event {
+    b.mu.Lock()
+    defer b.mu.Unlock()
+
+    batchMax := int64(-1)
+    for _, r := range raw {
+        if id, err := strconv.ParseInt(r.ID, 10, 64); err == nil && id > batchMax {
+            batchMax = id
+        }
+    }
+    if batchMax >= 0 && batchMax < b.highID {
+        log.Printf("events: modem event log ID counter reset (was tracking up to %d, now seeing up to %d); assuming a modem reboot and resetting dedup state", b.highID, batchMax)
+        b.highID = 0
+    }
+
+    now := time.Now().UnixNano()
+    maxID := b.highID
+    var fresh []Event
+    for _, r := range raw {
+        id, err := strconv.ParseInt(r.ID, 10, 64)
+        if err != nil || id <= b.highID {
+            continue
+        }
+        if id > maxID {
+            maxID = id
+        }
+        ts := now
+        if parsed, ok := parseEventTime(r.Time); ok {
+            ts = parsed
+        }
+        b.seq++
+        fresh = append(fresh, Event{
+            ID:          r.ID,
+            Time:        r.Time,
+            TimestampNS: ts,
+            Level:       r.Level,
+            Desc:        r.Desc,
+            ModemHost:   host,
+            Seq:         b.seq,
+        })
+        b.NewTotal.WithLabelValues(strings.ToLower(r.Level)).Inc()
+    }
+    b.highID = maxID
+
+    b.ring = append(b.ring, fresh...)
+    if len(b.ring) > b.capacity {
+        b.ring = b.ring[len(b.ring)-b.capacity:]
+    }
+    for ch := range b.subs {
+        for _, e := range fresh {
+            select {
+            case ch <- e:
+            default:
+                // Slow subscriber; drop rather than block ingestion.
+            }
+        }
+    }
+    return fresh
+}
+
+// Recent returns up to n of the most recently ingested entries, oldest
+// first.  n <= 0 returns every entry currently in the ring.
+func (b *Bus) Recent(n int) []Event {
+    b.mu.Lock()
+    defer b.mu.Unlock()
+    if n <= 0 || n > len(b.ring) {
+        n = len(b.ring)
+    }
+    start := len(b.ring) - n
+    out := make([]Event, n)
+    copy(out, b.ring[start:])
+    return out
+}
+
+// Subscribe registers a channel that receives events ingested after this
+// call.  The channel buffers 64 events; while it is full, further events
+// are dropped for this subscriber rather than blocking Ingest.  The
+// returned cancel func must be called to unregister the subscriber and
+// release its channel.
+func (b *Bus) Subscribe() (<-chan Event, func()) {
+    ch := make(chan Event, 64)
+    b.mu.Lock()
+    b.subs[ch] = struct{}{}
+    b.mu.Unlock()
+    cancel := func() {
+        b.mu.Lock()
+        delete(b.subs, ch)
+        close(ch)
+        b.mu.Unlock()
+    }
+    return ch, cancel
+}
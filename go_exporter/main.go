@@ -1,28 +1,32 @@
 package main
 
 // This Go exporter provides a Prometheus metrics endpoint and a small log
-// endpoint for the Arris SB8200 cable modem.  It logs into the modem,
-// fetches XML data from the modem’s internal API and exposes status,
-// downstream/upstream channel metrics, configuration parameters and event
-// counts.  All configuration can be set via environment variables so it
+// endpoint for the Arris SB8200 cable modem.  It logs into the modem and
+// exposes status, downstream/upstream channel metrics, configuration
+// parameters and event counts.  Each data source is a self-contained probe
+// in the probe package; see probe.go for how probes are registered and
+// selected.  All configuration can be set via environment variables so it
 // behaves sensibly inside Docker or Kubernetes.  See the accompanying
 // README for detailed usage instructions.
 
 import (
-    "bytes"
-    "encoding/xml"
+    "context"
+    "encoding/json"
+    "errors"
     "fmt"
-    "io/ioutil"
     "log"
     "net/http"
-    "net/http/cookiejar"
     "os"
     "strconv"
     "strings"
+    "sync"
     "time"
 
     "github.com/prometheus/client_golang/prometheus"
     "github.com/prometheus/client_golang/prometheus/promhttp"
+
+    "github.com/MaxwellDPS/arris_sb8200_exporter/backoff"
+    "github.com/MaxwellDPS/arris_sb8200_exporter/probe"
 )
 
 // -----------------------------------------------------------------------------
@@ -34,20 +38,34 @@ import (
 //   SB8200_USER          – username for modem login (default admin)
 //   SB8200_PASSWORD      – password for modem login (required – no default)
 //   SB8200_PORT          – local port on which to expose the exporter (default 9215)
-//   SB8200_POLL_INTERVAL – polling interval in seconds (default 15)
+//   SB8200_PROBES        – comma-separated list of probes to run on each
+//                          scrape (default status,config,downstream,upstream,eventlog)
+//   SB8200_PROBE_TIMEOUT – per-probe collection timeout in seconds (default 10)
 //   SB8200_LOGS_MAX      – number of log entries to keep for the /logs endpoint (default 100)
+//   SB8200_BACKOFF_BASE_DELAY        – initial retry backoff in seconds (default 1)
+//   SB8200_BACKOFF_MAX_DELAY         – maximum retry backoff in seconds (default 120)
+//   SB8200_BACKOFF_FACTOR            – backoff growth factor per retry (default 1.6)
+//   SB8200_BACKOFF_JITTER            – backoff jitter fraction, 0-1 (default 0.2)
+//   SB8200_BACKOFF_FAILURE_THRESHOLD – consecutive login failures before the
+//                                       circuit breaker opens (default 1)
 //
 // When running inside Docker these values are typically supplied via the
 // environment directive in docker‑compose.yml or via `-e` flags to
 // `docker run`.
 
 var (
-    modemHost    = getEnv("SB8200_HOST", "192.168.100.1")
-    username     = getEnv("SB8200_USER", "admin")
-    password     = os.Getenv("SB8200_PASSWORD")
-    listenPort   = getEnv("SB8200_PORT", "9215")
-    pollInterval = getEnvInt("SB8200_POLL_INTERVAL", 15)
-    maxLogs      = getEnvInt("SB8200_LOGS_MAX", 100)
+    modemHost        = getEnv("SB8200_HOST", "192.168.100.1")
+    username         = getEnv("SB8200_USER", "admin")
+    password         = os.Getenv("SB8200_PASSWORD")
+    listenPort       = getEnv("SB8200_PORT", "9215")
+    probeNames       = getEnvList("SB8200_PROBES", []string{"status", "config", "downstream", "upstream", "eventlog"})
+    probeTimeout     = getEnvInt("SB8200_PROBE_TIMEOUT", 10)
+    maxLogs          = getEnvInt("SB8200_LOGS_MAX", 100)
+    backoffBaseDelay = getEnvInt("SB8200_BACKOFF_BASE_DELAY", 1)
+    backoffMaxDelay  = getEnvInt("SB8200_BACKOFF_MAX_DELAY", 120)
+    backoffFactor    = getEnvFloat("SB8200_BACKOFF_FACTOR", 1.6)
+    backoffJitter    = getEnvFloat("SB8200_BACKOFF_JITTER", 0.2)
+    failureThreshold = getEnvInt("SB8200_BACKOFF_FAILURE_THRESHOLD", 1)
 )
 
 func getEnv(key, def string) string {
@@ -70,398 +88,304 @@ func getEnvInt(key string, def int) int {
     return i
 }
 
-// -----------------------------------------------------------------------------
-// XML response structures
-//
-// The modem returns XML for various "fun" values.  A handful of structs are
-// defined here to unmarshal the relevant parts of those responses.  Should
-// Arris change their firmware or add/remove elements these structs may need
-// adjustment.  Fields not required by the exporter are omitted.
-
-// StatusResponse holds high‑level modem status.  fun=1
-type StatusResponse struct {
-    XMLName        xml.Name `xml:"data"`
-    CMStatus       string   `xml:"cm_status"`
-    CMSystemUptime string   `xml:"cm_system_uptime"`
-    SwVersion      string   `xml:"SwVersion"`
-    PrimaryFreq    string   `xml:"freq"`
-    PrimaryPow     string   `xml:"pow"`
-    PrimarySnr     string   `xml:"snr"`
+func getEnvFloat(key string, def float64) float64 {
+    val := os.Getenv(key)
+    if val == "" {
+        return def
+    }
+    f, err := strconv.ParseFloat(val, 64)
+    if err != nil {
+        return def
+    }
+    return f
 }
 
-// DownstreamChannel represents one bonded downstream channel.  fun=16
-// Field names reflect the XML keys used by the modem’s API.  Some
-// implementations may differ; adjust accordingly if your modem returns
-// different element names.
-type DownstreamChannel struct {
-    ChannelID       string `xml:"id"`
-    LockStatus      string `xml:"lock"`
-    Modulation      string `xml:"mod"`
-    Frequency       string `xml:"freq"`
-    Power           string `xml:"pow"`
-    SNR             string `xml:"snr"`
-    Correcteds      string `xml:"correcteds"`
-    Uncorrectables  string `xml:"uncorrectables"`
+func getEnvList(key string, def []string) []string {
+    val := os.Getenv(key)
+    if val == "" {
+        return def
+    }
+    var out []string
+    for _, part := range strings.Split(val, ",") {
+        if part = strings.TrimSpace(part); part != "" {
+            out = append(out, part)
+        }
+    }
+    if len(out) == 0 {
+        return def
+    }
+    return out
 }
 
-// DownstreamResponse holds the downstream channel table.  fun=16
-type DownstreamResponse struct {
-    Channels []DownstreamChannel `xml:"chnl"`
-}
+// -----------------------------------------------------------------------------
+// Prometheus collector
+//
+// sb8200Collector implements prometheus.Collector directly instead of
+// registering a fixed set of GaugeVecs.  Each scrape logs into the modem
+// once, then runs every enabled probe with its own timeout and emits
+// ConstMetrics straight onto the scrape's channel; a probe that errors or
+// times out only costs that probe's metrics, and stale channel labels (e.g.
+// after the bonding set shrinks) disappear instead of lingering.
 
-// UpstreamChannel represents one bonded upstream channel.  fun=18
-type UpstreamChannel struct {
-    ChannelID  string `xml:"id"`
-    LockStatus string `xml:"lock"`
-    Modulation string `xml:"mod"`
-    Frequency  string `xml:"freq"`
-    Power      string `xml:"pow"`
-}
+var (
+    upDesc           = prometheus.NewDesc("sb8200_modem_up", "Whether the last scrape of the modem was successful (1) or failed (0)", nil, nil)
+    circuitStateDesc = prometheus.NewDesc("sb8200_modem_circuit_state", "Login circuit breaker state (0=closed, 1=open, 2=half_open)", nil, nil)
+)
 
-// UpstreamResponse holds the upstream channel table.  fun=18
-type UpstreamResponse struct {
-    Channels []UpstreamChannel `xml:"chnl"`
+type sb8200Collector struct {
+    session *probe.ModemSession
+    probes  []probe.Probe
+    timeout time.Duration
+    breaker *backoff.CircuitBreaker
+
+    // loginMu serialises every login attempt, whether the initial one at
+    // the top of Collect or a mid-scrape re-login triggered by a probe
+    // seeing probe.ErrReauthRequired, so concurrent probes never hammer the
+    // modem with simultaneous login POSTs and every attempt is accounted
+    // for by breaker.
+    loginMu sync.Mutex
 }
 
-// ConfigResponse holds selected configuration values.  fun=8
-type ConfigResponse struct {
-    XMLName     xml.Name `xml:"data"`
-    ChannelPlan string   `xml:"ChannelPlan"`
-    LEDControl  string   `xml:"LEDControl"`
-    EeePortState string  `xml:"EeePortState"`
+func newSB8200Collector(session *probe.ModemSession, names []string, timeout time.Duration, breaker *backoff.CircuitBreaker) *sb8200Collector {
+    return &sb8200Collector{
+        session: session,
+        probes:  probe.Enabled(names),
+        timeout: timeout,
+        breaker: breaker,
+    }
 }
 
-// EventEntry represents one entry in the modem’s event log.  fun=20
-type EventEntry struct {
-    ID    string `xml:"id"`
-    Time  string `xml:"time"`
-    Level string `xml:"level"`
-    Desc  string `xml:"desc"`
+func (c *sb8200Collector) Describe(ch chan<- *prometheus.Desc) {
+    ch <- upDesc
+    ch <- circuitStateDesc
+    for _, p := range c.probes {
+        p.Describe(ch)
+    }
 }
 
-// EventLogResponse wraps the event log list.  fun=20
-type EventLogResponse struct {
-    LogNum   int          `xml:"log_num"`
-    EventLog []EventEntry `xml:"eventlog"`
-}
+func (c *sb8200Collector) Collect(ch chan<- prometheus.Metric) {
+    defer func() {
+        ch <- prometheus.MustNewConstMetric(circuitStateDesc, prometheus.GaugeValue, float64(c.breaker.State()))
+    }()
 
-// -----------------------------------------------------------------------------
-// Prometheus metrics definitions
+    if password == "" {
+        log.Println("SB8200_PASSWORD is not set; skipping scrape")
+        ch <- prometheus.MustNewConstMetric(upDesc, prometheus.GaugeValue, 0)
+        return
+    }
+    if err := c.login(); err != nil {
+        log.Printf("Modem login failed: %v", err)
+        ch <- prometheus.MustNewConstMetric(upDesc, prometheus.GaugeValue, 0)
+        return
+    }
 
-var (
-    // upMetric indicates whether the last scrape was successful (1) or not (0).
-    upMetric = prometheus.NewGauge(prometheus.GaugeOpts{
-        Name: "sb8200_modem_up",
-        Help: "Whether the last scrape of the modem was successful (1) or failed (0)",
-    })
-
-    downstreamFreq = prometheus.NewGauge(prometheus.GaugeOpts{
-        Name: "sb8200_downstream_frequency_hz",
-        Help: "Primary downstream frequency in Hz",
-    })
-    downstreamPower = prometheus.NewGauge(prometheus.GaugeOpts{
-        Name: "sb8200_downstream_power_dbmv",
-        Help: "Primary downstream power in dBmV",
-    })
-    downstreamSnr = prometheus.NewGauge(prometheus.GaugeOpts{
-        Name: "sb8200_downstream_snr_db",
-        Help: "Primary downstream SNR in dB",
-    })
-
-    channelPlan = prometheus.NewGauge(prometheus.GaugeOpts{
-        Name: "sb8200_channel_plan",
-        Help: "Channel plan (1=North America, 2=Europe, etc)",
-    })
-    ledStatus = prometheus.NewGauge(prometheus.GaugeOpts{
-        Name: "sb8200_led_status",
-        Help: "LED status (0=Off, 1=On)",
-    })
-    eeeState = prometheus.NewGauge(prometheus.GaugeOpts{
-        Name: "sb8200_eee_state",
-        Help: "Energy Efficient Ethernet port state (0=Disabled, 1=Enabled)",
-    })
-
-    // Downstream channel metrics keyed by channel ID
-    downstreamChannelFreq = prometheus.NewGaugeVec(prometheus.GaugeOpts{
-        Name: "sb8200_downstream_channel_frequency_hz",
-        Help: "Downstream channel frequency in Hz",
-    }, []string{"channel"})
-    downstreamChannelPower = prometheus.NewGaugeVec(prometheus.GaugeOpts{
-        Name: "sb8200_downstream_channel_power_dbmv",
-        Help: "Downstream channel power in dBmV",
-    }, []string{"channel"})
-    downstreamChannelSnr = prometheus.NewGaugeVec(prometheus.GaugeOpts{
-        Name: "sb8200_downstream_channel_snr_db",
-        Help: "Downstream channel SNR in dB",
-    }, []string{"channel"})
-    downstreamChannelLock = prometheus.NewGaugeVec(prometheus.GaugeOpts{
-        Name: "sb8200_downstream_channel_locked",
-        Help: "Downstream channel lock status (1=Locked, 0=Unlocked)",
-    }, []string{"channel"})
-    downstreamChannelCorrected = prometheus.NewGaugeVec(prometheus.GaugeOpts{
-        Name: "sb8200_downstream_channel_corrected",
-        Help: "Corrected codeword count per downstream channel",
-    }, []string{"channel"})
-    downstreamChannelUncorrectable = prometheus.NewGaugeVec(prometheus.GaugeOpts{
-        Name: "sb8200_downstream_channel_uncorrectable",
-        Help: "Uncorrectable codeword count per downstream channel",
-    }, []string{"channel"})
-
-    // Upstream channel metrics keyed by channel ID
-    upstreamChannelPower = prometheus.NewGaugeVec(prometheus.GaugeOpts{
-        Name: "sb8200_upstream_channel_power_dbmv",
-        Help: "Upstream channel power in dBmV",
-    }, []string{"channel"})
-    upstreamChannelFreq = prometheus.NewGaugeVec(prometheus.GaugeOpts{
-        Name: "sb8200_upstream_channel_frequency_hz",
-        Help: "Upstream channel center frequency in Hz",
-    }, []string{"channel"})
-    upstreamChannelLock = prometheus.NewGaugeVec(prometheus.GaugeOpts{
-        Name: "sb8200_upstream_channel_locked",
-        Help: "Upstream channel lock status (1=Locked, 0=Unlocked)",
-    }, []string{"channel"})
-
-    // Event log counts by level (critical, warning, notice, etc)
-    eventLogCount = prometheus.NewGaugeVec(prometheus.GaugeOpts{
-        Name: "sb8200_eventlog_count",
-        Help: "Number of event log entries by severity level",
-    }, []string{"level"})
-)
+    var wg sync.WaitGroup
+    for _, p := range c.probes {
+        wg.Add(1)
+        go func(p probe.Probe) {
+            defer wg.Done()
+            c.collectProbe(p, ch)
+        }(p)
+    }
+    wg.Wait()
 
-func init() {
-    prometheus.MustRegister(
-        upMetric,
-        downstreamFreq,
-        downstreamPower,
-        downstreamSnr,
-        channelPlan,
-        ledStatus,
-        eeeState,
-        downstreamChannelFreq,
-        downstreamChannelPower,
-        downstreamChannelSnr,
-        downstreamChannelLock,
-        downstreamChannelCorrected,
-        downstreamChannelUncorrectable,
-        upstreamChannelPower,
-        upstreamChannelFreq,
-        upstreamChannelLock,
-        eventLogCount,
-    )
+    ch <- prometheus.MustNewConstMetric(upDesc, prometheus.GaugeValue, 1)
 }
 
-// -----------------------------------------------------------------------------
-// HTTP client and helper routines
-
-var client *http.Client
-var recentLogs []EventEntry
-
-// login performs the SOAP login sequence required before calling any
-// authenticated endpoints.  The modem sets a session cookie on success.
-func login() error {
-    loginXML := fmt.Sprintf(`<?xml version="1.0" encoding="utf-8"?>
-<soap:Envelope xmlns:soap="http://schemas.xmlsoap.org/soap/envelope/">
-  <soap:Body>
-    <Login>
-      <Username>%s</Username>
-      <LoginPassword>%s</LoginPassword>
-    </Login>
-  </soap:Body>
-</soap:Envelope>`, username, password)
-    url := fmt.Sprintf("http://%s/xml/login.xml", modemHost)
-    req, err := http.NewRequest("POST", url, bytes.NewBuffer([]byte(loginXML)))
-    if err != nil {
-        return err
+// errCircuitOpen is returned by login when the circuit breaker is refusing
+// attempts; it is only used for the log message, not exposed to callers.
+var errCircuitOpen = errors.New("circuit breaker open; skipping login until cooldown elapses")
+
+// login serialises every login attempt through breaker, so the initial
+// login at the top of Collect and any mid-scrape re-logins triggered by
+// probes hitting probe.ErrReauthRequired share one gate: concurrent probes
+// never issue simultaneous login POSTs, and every failure or success is
+// recorded on breaker so its state reflects reality.
+func (c *sb8200Collector) login() error {
+    c.loginMu.Lock()
+    defer c.loginMu.Unlock()
+    if !c.breaker.Allow() {
+        return errCircuitOpen
     }
-    req.Header.Set("Content-Type", "text/xml")
-    resp, err := client.Do(req)
-    if err != nil {
+    if err := c.session.Login(); err != nil {
+        c.breaker.OnFailure()
         return err
     }
-    defer resp.Body.Close()
-    if resp.StatusCode != http.StatusOK {
-        body, _ := ioutil.ReadAll(resp.Body)
-        return fmt.Errorf("login failed: %s", string(body))
-    }
+    c.breaker.OnSuccess()
     return nil
 }
 
-// fetchXML posts a small form payload to the modem’s getter endpoint.  The
-// payload is typically "fun=n" where n selects the type of data returned.
-func fetchXML(payload string) ([]byte, error) {
-    url := fmt.Sprintf("http://%s/xml/getter.xml", modemHost)
-    req, err := http.NewRequest("POST", url, bytes.NewBufferString(payload))
-    if err != nil {
-        return nil, err
-    }
-    req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
-    resp, err := client.Do(req)
-    if err != nil {
-        return nil, err
-    }
-    defer resp.Body.Close()
-    return ioutil.ReadAll(resp.Body)
-}
-
-// parseFloat safely converts a string to float64.  Empty or invalid strings
-// evaluate to 0.0.
-func parseFloat(s string) float64 {
-    if s == "" {
-        return 0.0
+// collectProbe runs a single probe under its own timeout, re-logging in and
+// retrying once if the probe's response indicates the session expired
+// mid-scrape.
+func (c *sb8200Collector) collectProbe(p probe.Probe, ch chan<- prometheus.Metric) {
+    ctx, cancel := context.WithTimeout(context.Background(), c.timeout)
+    defer cancel()
+    err := p.Collect(ctx, c.session, ch)
+    if err == probe.ErrReauthRequired {
+        if loginErr := c.login(); loginErr != nil {
+            log.Printf("re-login for probe %q failed: %v", p.Name(), loginErr)
+            return
+        }
+        // The re-login may have consumed a meaningful chunk of the
+        // original deadline (or waited on loginMu behind another probe's
+        // login); give the
+        // retry its own fresh budget rather than racing the leftovers of
+        // ctx.
+        retryCtx, retryCancel := context.WithTimeout(context.Background(), c.timeout)
+        err = p.Collect(retryCtx, c.session, ch)
+        retryCancel()
     }
-    // Remove any non‑numeric suffixes (e.g. dBmV, Hz) if present
-    s = strings.TrimSpace(s)
-    s = strings.TrimSuffix(s, "dBmV")
-    s = strings.TrimSuffix(s, "dB")
-    s = strings.TrimSuffix(s, "Hz")
-    f, err := strconv.ParseFloat(s, 64)
     if err != nil {
-        return 0.0
-    }
-    return f
-}
-
-// statusToFloat converts lock/status strings to 1 or 0.  Recognises
-// "locked", "1", "on" as true.
-func statusToFloat(s string) float64 {
-    s = strings.TrimSpace(strings.ToLower(s))
-    switch s {
-    case "1", "true", "locked", "yes", "on":
-        return 1.0
-    default:
-        return 0.0
+        log.Printf("probe %q failed: %v", p.Name(), err)
     }
 }
 
-// saveRecentLogs truncates the global log buffer to maxLogs entries.
-func saveRecentLogs(logs []EventEntry) {
-    if len(logs) > maxLogs {
-        recentLogs = logs[len(logs)-maxLogs:]
-    } else {
-        recentLogs = logs
-    }
-}
+// -----------------------------------------------------------------------------
+// /logs HTTP handlers
+//
+// logsHandler serves a point-in-time snapshot; logsStreamHandler and
+// logsSSEHandler push newly observed entries as they're ingested, via the
+// events.Bus kept by the eventlog probe, so downstream log pipelines don't
+// have to poll and dedupe /logs themselves.
 
 // logsHandler serves the most recent event log entries as plain text.  The
 // optional query parameter ?count=n restricts the number of lines returned.
 func logsHandler(w http.ResponseWriter, r *http.Request) {
+    bus := probe.EventBus()
+    if bus == nil {
+        http.Error(w, "event log unavailable", http.StatusServiceUnavailable)
+        return
+    }
     n := maxLogs
     if val := r.URL.Query().Get("count"); val != "" {
         if i, err := strconv.Atoi(val); err == nil && i > 0 && i < n {
             n = i
         }
     }
-    // Determine start index based on requested count
-    start := 0
-    if len(recentLogs) > n {
-        start = len(recentLogs) - n
-    }
-    for _, entry := range recentLogs[start:] {
-        fmt.Fprintf(w, "%s [%s] %s\n", entry.Time, entry.Level, entry.Desc)
+    for _, e := range bus.Recent(n) {
+        fmt.Fprintf(w, "%s [%s] %s\n", e.Time, e.Level, e.Desc)
     }
 }
 
-// updateMetrics logs into the modem, fetches all relevant XML documents,
-// updates the Prometheus metrics and stores event logs.
-func updateMetrics() {
-    if password == "" {
-        log.Println("SB8200_PASSWORD is not set; skipping scrape")
-        upMetric.Set(0)
+// logsStreamHandler serves newly observed event log entries as
+// newline-delimited JSON over a chunked HTTP response (long-poll: the
+// connection stays open and each new event is flushed as it's ingested), with
+// a "# heartbeat" comment line every 15s to keep idle connections alive.
+func logsStreamHandler(w http.ResponseWriter, r *http.Request) {
+    bus := probe.EventBus()
+    if bus == nil {
+        http.Error(w, "event log unavailable", http.StatusServiceUnavailable)
         return
     }
-    if err := login(); err != nil {
-        log.Printf("Modem login failed: %v", err)
-        upMetric.Set(0)
+    flusher, ok := w.(http.Flusher)
+    if !ok {
+        http.Error(w, "streaming unsupported", http.StatusInternalServerError)
         return
     }
-    upMetric.Set(1)
-    // Status values
-    raw, err := fetchXML("fun=1")
-    if err == nil {
-        var statusResp StatusResponse
-        if err := xml.Unmarshal(raw, &statusResp); err == nil {
-            downstreamFreq.Set(parseFloat(statusResp.PrimaryFreq))
-            downstreamPower.Set(parseFloat(statusResp.PrimaryPow))
-            downstreamSnr.Set(parseFloat(statusResp.PrimarySnr))
-        }
-    }
-    // Configuration values
-    raw, err = fetchXML("fun=8")
-    if err == nil {
-        var cfgResp ConfigResponse
-        if err := xml.Unmarshal(raw, &cfgResp); err == nil {
-            channelPlan.Set(parseFloat(cfgResp.ChannelPlan))
-            ledStatus.Set(parseFloat(cfgResp.LEDControl))
-            eeeState.Set(parseFloat(cfgResp.EeePortState))
-        }
-    }
-    // Downstream channels
-    raw, err = fetchXML("fun=16")
-    if err == nil {
-        var dsResp DownstreamResponse
-        if err := xml.Unmarshal(raw, &dsResp); err == nil {
-            for _, ch := range dsResp.Channels {
-                id := ch.ChannelID
-                downstreamChannelFreq.WithLabelValues(id).Set(parseFloat(ch.Frequency))
-                downstreamChannelPower.WithLabelValues(id).Set(parseFloat(ch.Power))
-                downstreamChannelSnr.WithLabelValues(id).Set(parseFloat(ch.SNR))
-                downstreamChannelLock.WithLabelValues(id).Set(statusToFloat(ch.LockStatus))
-                downstreamChannelCorrected.WithLabelValues(id).Set(parseFloat(ch.Correcteds))
-                downstreamChannelUncorrectable.WithLabelValues(id).Set(parseFloat(ch.Uncorrectables))
+    w.Header().Set("Content-Type", "application/x-ndjson")
+
+    sub, cancel := bus.Subscribe()
+    defer cancel()
+    heartbeat := time.NewTicker(15 * time.Second)
+    defer heartbeat.Stop()
+    enc := json.NewEncoder(w)
+
+    for {
+        select {
+        case <-r.Context().Done():
+            return
+        case e, ok := <-sub:
+            if !ok {
+                return
             }
-        }
-    }
-    // Upstream channels
-    raw, err = fetchXML("fun=18")
-    if err == nil {
-        var usResp UpstreamResponse
-        if err := xml.Unmarshal(raw, &usResp); err == nil {
-            for _, ch := range usResp.Channels {
-                id := ch.ChannelID
-                upstreamChannelPower.WithLabelValues(id).Set(parseFloat(ch.Power))
-                upstreamChannelFreq.WithLabelValues(id).Set(parseFloat(ch.Frequency))
-                upstreamChannelLock.WithLabelValues(id).Set(statusToFloat(ch.LockStatus))
+            if err := enc.Encode(e); err != nil {
+                return
             }
+            flusher.Flush()
+        case <-heartbeat.C:
+            fmt.Fprint(w, "# heartbeat\n")
+            flusher.Flush()
         }
     }
-    // Event log and counts
-    raw, err = fetchXML("fun=20")
-    if err == nil {
-        var logResp EventLogResponse
-        if err := xml.Unmarshal(raw, &logResp); err == nil {
-            counts := map[string]int{}
-            for _, entry := range logResp.EventLog {
-                lvl := strings.ToLower(entry.Level)
-                counts[lvl]++
+}
+
+// logsSSEHandler serves the same newly observed event log entries as
+// Server-Sent Events, for browser consumption via EventSource.
+func logsSSEHandler(w http.ResponseWriter, r *http.Request) {
+    bus := probe.EventBus()
+    if bus == nil {
+        http.Error(w, "event log unavailable", http.StatusServiceUnavailable)
+        return
+    }
+    flusher, ok := w.(http.Flusher)
+    if !ok {
+        http.Error(w, "streaming unsupported", http.StatusInternalServerError)
+        return
+    }
+    w.Header().Set("Content-Type", "text/event-stream")
+    w.Header().Set("Cache-Control", "no-cache")
+    w.Header().Set("Connection", "keep-alive")
+
+    sub, cancel := bus.Subscribe()
+    defer cancel()
+    heartbeat := time.NewTicker(15 * time.Second)
+    defer heartbeat.Stop()
+
+    for {
+        select {
+        case <-r.Context().Done():
+            return
+        case e, ok := <-sub:
+            if !ok {
+                return
             }
-            eventLogCount.Reset()
-            for lvl, c := range counts {
-                eventLogCount.WithLabelValues(lvl).Set(float64(c))
+            payload, err := json.Marshal(e)
+            if err != nil {
+                continue
             }
-            saveRecentLogs(logResp.EventLog)
+            fmt.Fprintf(w, "data: %s\n\n", payload)
+            flusher.Flush()
+        case <-heartbeat.C:
+            fmt.Fprint(w, ": heartbeat\n\n")
+            flusher.Flush()
         }
     }
 }
 
 // -----------------------------------------------------------------------------
-// main initialises the HTTP client and schedules the polling loop.  It
-// registers the Prometheus handler and the log endpoint then starts the
-// webserver.  The exporter exits fatally if the server cannot be started.
+// main wires up the modem session and the probe-backed collector, registers
+// the Prometheus and log HTTP handlers, then starts the webserver.  The
+// exporter exits fatally if the server cannot be started.
 func main() {
-    jar, _ := cookiejar.New(nil)
-    client = &http.Client{
-        Timeout: 10 * time.Second,
-        Jar:     jar,
+    if bus := probe.EventBus(); bus != nil {
+        bus.SetCapacity(maxLogs)
     }
-    // Periodic polling loop
-    go func() {
-        for {
-            updateMetrics()
-            time.Sleep(time.Duration(pollInterval) * time.Second)
-        }
-    }()
+
+    // The client's own Timeout is a safety net against a connection that
+    // hangs below the HTTP layer; the authoritative per-probe deadline is
+    // the ctx passed into FetchXML by collectProbe, derived from the same
+    // SB8200_PROBE_TIMEOUT value.
+    session, err := probe.NewModemSession(modemHost, username, password, time.Duration(probeTimeout)*time.Second)
+    if err != nil {
+        log.Fatalf("failed to create modem session: %v", err)
+    }
+
+    strategy := backoff.Strategy{
+        BaseDelay: time.Duration(backoffBaseDelay) * time.Second,
+        Factor:    backoffFactor,
+        Jitter:    backoffJitter,
+        MaxDelay:  time.Duration(backoffMaxDelay) * time.Second,
+    }
+    breaker := backoff.NewCircuitBreaker(strategy, failureThreshold)
+
+    collector := newSB8200Collector(session, probeNames, time.Duration(probeTimeout)*time.Second, breaker)
+    prometheus.MustRegister(collector)
+
     http.Handle("/metrics", promhttp.Handler())
     http.HandleFunc("/logs", logsHandler)
-    log.Printf("Listening on :%s for SB8200 exporter", listenPort)
+    http.HandleFunc("/logs/stream", logsStreamHandler)
+    http.HandleFunc("/logs/sse", logsSSEHandler)
+    log.Printf("Listening on :%s for SB8200 exporter (probes: %s)", listenPort, strings.Join(probeNames, ","))
     log.Fatal(http.ListenAndServe(":"+listenPort, nil))
-}
\ No newline at end of file
+}
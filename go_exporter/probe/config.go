@@ -0,0 +1,52 @@
+package probe
+
+import (
+    "context"
+    "encoding/xml"
+
+    "github.com/prometheus/client_golang/prometheus"
+)
+
+func init() {
+    Register(configProbe{})
+}
+
+// configResponse holds selected configuration values.  fun=8
+type configResponse struct {
+    XMLName      xml.Name `xml:"data"`
+    ChannelPlan  string   `xml:"ChannelPlan"`
+    LEDControl   string   `xml:"LEDControl"`
+    EeePortState string   `xml:"EeePortState"`
+}
+
+var (
+    channelPlanDesc = prometheus.NewDesc("sb8200_channel_plan", "Channel plan (1=North America, 2=Europe, etc)", nil, nil)
+    ledStatusDesc   = prometheus.NewDesc("sb8200_led_status", "LED status (0=Off, 1=On)", nil, nil)
+    eeeStateDesc    = prometheus.NewDesc("sb8200_eee_state", "Energy Efficient Ethernet port state (0=Disabled, 1=Enabled)", nil, nil)
+)
+
+// configProbe collects selected configuration values.  fun=8
+type configProbe struct{}
+
+func (configProbe) Name() string { return "config" }
+
+func (configProbe) Describe(ch chan<- *prometheus.Desc) {
+    ch <- channelPlanDesc
+    ch <- ledStatusDesc
+    ch <- eeeStateDesc
+}
+
+func (configProbe) Collect(ctx context.Context, session *ModemSession, ch chan<- prometheus.Metric) error {
+    raw, err := session.FetchXML(ctx, "fun=8")
+    if err != nil {
+        return err
+    }
+    var resp configResponse
+    if err := xml.Unmarshal(raw, &resp); err != nil {
+        return err
+    }
+    ch <- prometheus.MustNewConstMetric(channelPlanDesc, prometheus.GaugeValue, parseFloat(resp.ChannelPlan))
+    ch <- prometheus.MustNewConstMetric(ledStatusDesc, prometheus.GaugeValue, parseFloat(resp.LEDControl))
+    ch <- prometheus.MustNewConstMetric(eeeStateDesc, prometheus.GaugeValue, parseFloat(resp.EeePortState))
+    return nil
+}
@@ -0,0 +1,76 @@
+package probe
+
+import (
+    "context"
+    "encoding/xml"
+
+    "github.com/prometheus/client_golang/prometheus"
+)
+
+func init() {
+    Register(downstreamProbe{})
+}
+
+// downstreamChannel represents one bonded downstream channel.  fun=16
+// Field names reflect the XML keys used by the modem's API.  Some
+// implementations may differ; adjust accordingly if your modem returns
+// different element names.
+type downstreamChannel struct {
+    ChannelID      string `xml:"id"`
+    LockStatus     string `xml:"lock"`
+    Modulation     string `xml:"mod"`
+    Frequency      string `xml:"freq"`
+    Power          string `xml:"pow"`
+    SNR            string `xml:"snr"`
+    Correcteds     string `xml:"correcteds"`
+    Uncorrectables string `xml:"uncorrectables"`
+}
+
+// downstreamResponse holds the downstream channel table.  fun=16
+type downstreamResponse struct {
+    Channels []downstreamChannel `xml:"chnl"`
+}
+
+var (
+    downstreamChannelFreqDesc          = prometheus.NewDesc("sb8200_downstream_channel_frequency_hz", "Downstream channel frequency in Hz", []string{"channel"}, nil)
+    downstreamChannelPowerDesc         = prometheus.NewDesc("sb8200_downstream_channel_power_dbmv", "Downstream channel power in dBmV", []string{"channel"}, nil)
+    downstreamChannelSnrDesc           = prometheus.NewDesc("sb8200_downstream_channel_snr_db", "Downstream channel SNR in dB", []string{"channel"}, nil)
+    downstreamChannelLockDesc          = prometheus.NewDesc("sb8200_downstream_channel_locked", "Downstream channel lock status (1=Locked, 0=Unlocked)", []string{"channel"}, nil)
+    downstreamChannelCorrectedDesc     = prometheus.NewDesc("sb8200_downstream_channel_corrected", "Corrected codeword count per downstream channel", []string{"channel"}, nil)
+    downstreamChannelUncorrectableDesc = prometheus.NewDesc("sb8200_downstream_channel_uncorrectable", "Uncorrectable codeword count per downstream channel", []string{"channel"}, nil)
+)
+
+// downstreamProbe collects the bonded downstream channel table.  fun=16
+type downstreamProbe struct{}
+
+func (downstreamProbe) Name() string { return "downstream" }
+
+func (downstreamProbe) Describe(ch chan<- *prometheus.Desc) {
+    ch <- downstreamChannelFreqDesc
+    ch <- downstreamChannelPowerDesc
+    ch <- downstreamChannelSnrDesc
+    ch <- downstreamChannelLockDesc
+    ch <- downstreamChannelCorrectedDesc
+    ch <- downstreamChannelUncorrectableDesc
+}
+
+func (downstreamProbe) Collect(ctx context.Context, session *ModemSession, ch chan<- prometheus.Metric) error {
+    raw, err := session.FetchXML(ctx, "fun=16")
+    if err != nil {
+        return err
+    }
+    var resp downstreamResponse
+    if err := xml.Unmarshal(raw, &resp); err != nil {
+        return err
+    }
+    for _, c := range resp.Channels {
+        id := c.ChannelID
+        ch <- prometheus.MustNewConstMetric(downstreamChannelFreqDesc, prometheus.GaugeValue, parseFloat(c.Frequency), id)
+        ch <- prometheus.MustNewConstMetric(downstreamChannelPowerDesc, prometheus.GaugeValue, parseFloat(c.Power), id)
+        ch <- prometheus.MustNewConstMetric(downstreamChannelSnrDesc, prometheus.GaugeValue, parseFloat(c.SNR), id)
+        ch <- prometheus.MustNewConstMetric(downstreamChannelLockDesc, prometheus.GaugeValue, statusToFloat(c.LockStatus), id)
+        ch <- prometheus.MustNewConstMetric(downstreamChannelCorrectedDesc, prometheus.GaugeValue, parseFloat(c.Correcteds), id)
+        ch <- prometheus.MustNewConstMetric(downstreamChannelUncorrectableDesc, prometheus.GaugeValue, parseFloat(c.Uncorrectables), id)
+    }
+    return nil
+}
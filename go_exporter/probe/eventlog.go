@@ -0,0 +1,93 @@
+package probe
+
+import (
+    "context"
+    "encoding/xml"
+    "strings"
+
+    "github.com/prometheus/client_golang/prometheus"
+
+    "github.com/MaxwellDPS/arris_sb8200_exporter/events"
+)
+
+const defaultEventBusCapacity = 100
+
+func init() {
+    Register(&eventlogProbe{bus: events.NewBus(defaultEventBusCapacity)})
+}
+
+// eventEntry represents one entry in the modem's event log.  fun=20
+type eventEntry struct {
+    ID    string `xml:"id"`
+    Time  string `xml:"time"`
+    Level string `xml:"level"`
+    Desc  string `xml:"desc"`
+}
+
+// eventLogResponse wraps the event log list.  fun=20
+type eventLogResponse struct {
+    LogNum   int          `xml:"log_num"`
+    EventLog []eventEntry `xml:"eventlog"`
+}
+
+var eventLogCountDesc = prometheus.NewDesc("sb8200_eventlog_count", "Number of event log entries by severity level", []string{"level"}, nil)
+
+// eventlogProbe collects the modem's event log.  fun=20
+//
+// The per-level count gauge reflects the full snapshot returned on each
+// scrape, as before.  New entries are additionally handed to an events.Bus,
+// which dedupes them against what's already been observed, retains them for
+// the /logs HTTP handler and streams them to /logs/stream and /logs/sse
+// subscribers.
+type eventlogProbe struct {
+    bus *events.Bus
+}
+
+func (p *eventlogProbe) Name() string { return "eventlog" }
+
+func (p *eventlogProbe) Describe(ch chan<- *prometheus.Desc) {
+    ch <- eventLogCountDesc
+    p.bus.NewTotal.Describe(ch)
+}
+
+func (p *eventlogProbe) Collect(ctx context.Context, session *ModemSession, ch chan<- prometheus.Metric) error {
+    raw, err := session.FetchXML(ctx, "fun=20")
+    if err != nil {
+        return err
+    }
+    var resp eventLogResponse
+    if err := xml.Unmarshal(raw, &resp); err != nil {
+        return err
+    }
+    counts := map[string]int{}
+    entries := make([]events.RawEntry, 0, len(resp.EventLog))
+    for _, e := range resp.EventLog {
+        lvl := strings.ToLower(e.Level)
+        counts[lvl]++
+        entries = append(entries, events.RawEntry{ID: e.ID, Time: e.Time, Level: e.Level, Desc: e.Desc})
+    }
+    for lvl, c := range counts {
+        ch <- prometheus.MustNewConstMetric(eventLogCountDesc, prometheus.GaugeValue, float64(c), lvl)
+    }
+    p.bus.Ingest(session.Host, entries)
+    p.bus.NewTotal.Collect(ch)
+    return nil
+}
+
+// eventlog returns the registered eventlogProbe, or nil if it was somehow
+// replaced or never registered.
+func eventlog() *eventlogProbe {
+    mu.Lock()
+    defer mu.Unlock()
+    p, _ := registry["eventlog"].(*eventlogProbe)
+    return p
+}
+
+// EventBus returns the events.Bus backing the eventlog probe, so main can
+// wire up the /logs, /logs/stream and /logs/sse HTTP handlers against it.
+func EventBus() *events.Bus {
+    if p := eventlog(); p != nil {
+        return p.bus
+    }
+    return nil
+}
@@ -0,0 +1,82 @@
+// Package probe defines the pluggable data-source abstraction used by the
+// SB8200 exporter.  Each probe owns a single modem "fun=" endpoint: it knows
+// how to describe its metrics and how to fetch and parse its own XML
+// document.  Probes register themselves from an init() function via
+// Register, so adding support for a new fun= ID (WAN/LAN stats, battery,
+// diagnostics, ...) never requires touching main.go.
+package probe
+
+import (
+    "context"
+    "fmt"
+    "sync"
+
+    "github.com/prometheus/client_golang/prometheus"
+)
+
+// Probe is a self-contained modem data source.  Describe and Collect mirror
+// prometheus.Collector so the exporter's top-level collector can simply fan
+// out to every enabled probe.  Collect is handed a session rather than
+// holding one itself, since the session (and its login state) is owned by
+// the caller and may be shared across probes within a single scrape.
+type Probe interface {
+    // Name identifies the probe for the SB8200_PROBES enable list and for
+    // log messages.  It must be stable and lower-case, e.g. "downstream".
+    Name() string
+
+    // Describe sends the static metric descriptors this probe can emit.
+    Describe(ch chan<- *prometheus.Desc)
+
+    // Collect fetches the probe's fun= endpoint via session and emits
+    // ConstMetrics to ch.  It returns an error if the fetch or the XML
+    // unmarshal failed; callers should treat that as this probe's scrape
+    // having failed without affecting other probes.
+    Collect(ctx context.Context, session *ModemSession, ch chan<- prometheus.Metric) error
+}
+
+var (
+    mu       sync.Mutex
+    registry = map[string]Probe{}
+)
+
+// Register adds a probe to the package-level registry.  It is intended to be
+// called from each probe's init() function and panics on a duplicate name,
+// mirroring prometheus.MustRegister.
+func Register(p Probe) {
+    mu.Lock()
+    defer mu.Unlock()
+    name := p.Name()
+    if _, exists := registry[name]; exists {
+        panic(fmt.Sprintf("probe: duplicate probe registered: %s", name))
+    }
+    registry[name] = p
+}
+
+// All returns every registered probe, in no particular order (registration
+// order is not preserved); callers that need a stable order should sort by
+// Name.
+func All() []Probe {
+    mu.Lock()
+    defer mu.Unlock()
+    out := make([]Probe, 0, len(registry))
+    for _, p := range registry {
+        out = append(out, p)
+    }
+    return out
+}
+
+// Enabled returns the registered probes named in names, in the order given.
+// Unknown names are ignored so a typo in SB8200_PROBES quietly drops that
+// probe instead of crashing the exporter; callers that want to surface the
+// typo should diff the result length against len(names).
+func Enabled(names []string) []Probe {
+    mu.Lock()
+    defer mu.Unlock()
+    out := make([]Probe, 0, len(names))
+    for _, name := range names {
+        if p, ok := registry[name]; ok {
+            out = append(out, p)
+        }
+    }
+    return out
+}
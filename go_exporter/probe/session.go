@@ -0,0 +1,94 @@
+package probe
+
+import (
+    "bytes"
+    "context"
+    "errors"
+    "fmt"
+    "io/ioutil"
+    "net/http"
+    "net/http/cookiejar"
+    "time"
+)
+
+// ErrReauthRequired is returned by FetchXML when the modem's response
+// indicates the session cookie is no longer valid (a 401, or a redirect
+// back to the login page), so the caller should Login again and retry.
+var ErrReauthRequired = errors.New("probe: session expired, re-login required")
+
+// ModemSession holds the HTTP client used to talk to a modem's internal XML
+// API, including the cookie jar that carries the login session cookie
+// across requests.  A session is created once and reused for every scrape;
+// probes must not retain a session between calls to Collect.
+type ModemSession struct {
+    Host     string
+    Username string
+    Password string
+    Client   *http.Client
+}
+
+// NewModemSession builds a ModemSession with its own cookie jar.
+func NewModemSession(host, username, password string, timeout time.Duration) (*ModemSession, error) {
+    jar, err := cookiejar.New(nil)
+    if err != nil {
+        return nil, err
+    }
+    return &ModemSession{
+        Host:     host,
+        Username: username,
+        Password: password,
+        Client:   &http.Client{Timeout: timeout, Jar: jar},
+    }, nil
+}
+
+// Login performs the SOAP login sequence required before calling any
+// authenticated endpoint.  The modem sets a session cookie on success.
+func (s *ModemSession) Login() error {
+    loginXML := fmt.Sprintf(`<?xml version="1.0" encoding="utf-8"?>
+<soap:Envelope xmlns:soap="http://schemas.xmlsoap.org/soap/envelope/">
+  <soap:Body>
+    <Login>
+      <Username>%s</Username>
+      <LoginPassword>%s</LoginPassword>
+    </Login>
+  </soap:Body>
+</soap:Envelope>`, s.Username, s.Password)
+    url := fmt.Sprintf("http://%s/xml/login.xml", s.Host)
+    req, err := http.NewRequest("POST", url, bytes.NewBufferString(loginXML))
+    if err != nil {
+        return err
+    }
+    req.Header.Set("Content-Type", "text/xml")
+    resp, err := s.Client.Do(req)
+    if err != nil {
+        return err
+    }
+    defer resp.Body.Close()
+    if resp.StatusCode != http.StatusOK {
+        body, _ := ioutil.ReadAll(resp.Body)
+        return fmt.Errorf("login failed: %s", string(body))
+    }
+    return nil
+}
+
+// FetchXML posts a small form payload to the modem's getter endpoint.  The
+// payload is typically "fun=n" where n selects the type of data returned.
+// ctx bounds the request, so a slow probe can be cut off without affecting
+// the session's other callers.
+func (s *ModemSession) FetchXML(ctx context.Context, payload string) ([]byte, error) {
+    url := fmt.Sprintf("http://%s/xml/getter.xml", s.Host)
+    req, err := http.NewRequestWithContext(ctx, "POST", url, bytes.NewBufferString(payload))
+    if err != nil {
+        return nil, err
+    }
+    req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+    resp, err := s.Client.Do(req)
+    if err != nil {
+        return nil, err
+    }
+    defer resp.Body.Close()
+    if resp.StatusCode == http.StatusUnauthorized || (resp.Request != nil && resp.Request.URL.Path != "/xml/getter.xml") {
+        return nil, ErrReauthRequired
+    }
+    return ioutil.ReadAll(resp.Body)
+}
@@ -0,0 +1,55 @@
+package probe
+
+import (
+    "context"
+    "encoding/xml"
+
+    "github.com/prometheus/client_golang/prometheus"
+)
+
+func init() {
+    Register(statusProbe{})
+}
+
+// statusResponse holds high‑level modem status.  fun=1
+type statusResponse struct {
+    XMLName        xml.Name `xml:"data"`
+    CMStatus       string   `xml:"cm_status"`
+    CMSystemUptime string   `xml:"cm_system_uptime"`
+    SwVersion      string   `xml:"SwVersion"`
+    PrimaryFreq    string   `xml:"freq"`
+    PrimaryPow     string   `xml:"pow"`
+    PrimarySnr     string   `xml:"snr"`
+}
+
+var (
+    statusFreqDesc = prometheus.NewDesc("sb8200_downstream_frequency_hz", "Primary downstream frequency in Hz", nil, nil)
+    statusPowDesc  = prometheus.NewDesc("sb8200_downstream_power_dbmv", "Primary downstream power in dBmV", nil, nil)
+    statusSnrDesc  = prometheus.NewDesc("sb8200_downstream_snr_db", "Primary downstream SNR in dB", nil, nil)
+)
+
+// statusProbe collects high‑level modem status.  fun=1
+type statusProbe struct{}
+
+func (statusProbe) Name() string { return "status" }
+
+func (statusProbe) Describe(ch chan<- *prometheus.Desc) {
+    ch <- statusFreqDesc
+    ch <- statusPowDesc
+    ch <- statusSnrDesc
+}
+
+func (statusProbe) Collect(ctx context.Context, session *ModemSession, ch chan<- prometheus.Metric) error {
+    raw, err := session.FetchXML(ctx, "fun=1")
+    if err != nil {
+        return err
+    }
+    var resp statusResponse
+    if err := xml.Unmarshal(raw, &resp); err != nil {
+        return err
+    }
+    ch <- prometheus.MustNewConstMetric(statusFreqDesc, prometheus.GaugeValue, parseFloat(resp.PrimaryFreq))
+    ch <- prometheus.MustNewConstMetric(statusPowDesc, prometheus.GaugeValue, parseFloat(resp.PrimaryPow))
+    ch <- prometheus.MustNewConstMetric(statusSnrDesc, prometheus.GaugeValue, parseFloat(resp.PrimarySnr))
+    return nil
+}
@@ -0,0 +1,61 @@
+package probe
+
+import (
+    "context"
+    "encoding/xml"
+
+    "github.com/prometheus/client_golang/prometheus"
+)
+
+func init() {
+    Register(upstreamProbe{})
+}
+
+// upstreamChannel represents one bonded upstream channel.  fun=18
+type upstreamChannel struct {
+    ChannelID  string `xml:"id"`
+    LockStatus string `xml:"lock"`
+    Modulation string `xml:"mod"`
+    Frequency  string `xml:"freq"`
+    Power      string `xml:"pow"`
+}
+
+// upstreamResponse holds the upstream channel table.  fun=18
+type upstreamResponse struct {
+    Channels []upstreamChannel `xml:"chnl"`
+}
+
+var (
+    upstreamChannelPowerDesc = prometheus.NewDesc("sb8200_upstream_channel_power_dbmv", "Upstream channel power in dBmV", []string{"channel"}, nil)
+    upstreamChannelFreqDesc  = prometheus.NewDesc("sb8200_upstream_channel_frequency_hz", "Upstream channel center frequency in Hz", []string{"channel"}, nil)
+    upstreamChannelLockDesc  = prometheus.NewDesc("sb8200_upstream_channel_locked", "Upstream channel lock status (1=Locked, 0=Unlocked)", []string{"channel"}, nil)
+)
+
+// upstreamProbe collects the bonded upstream channel table.  fun=18
+type upstreamProbe struct{}
+
+func (upstreamProbe) Name() string { return "upstream" }
+
+func (upstreamProbe) Describe(ch chan<- *prometheus.Desc) {
+    ch <- upstreamChannelPowerDesc
+    ch <- upstreamChannelFreqDesc
+    ch <- upstreamChannelLockDesc
+}
+
+func (upstreamProbe) Collect(ctx context.Context, session *ModemSession, ch chan<- prometheus.Metric) error {
+    raw, err := session.FetchXML(ctx, "fun=18")
+    if err != nil {
+        return err
+    }
+    var resp upstreamResponse
+    if err := xml.Unmarshal(raw, &resp); err != nil {
+        return err
+    }
+    for _, c := range resp.Channels {
+        id := c.ChannelID
+        ch <- prometheus.MustNewConstMetric(upstreamChannelPowerDesc, prometheus.GaugeValue, parseFloat(c.Power), id)
+        ch <- prometheus.MustNewConstMetric(upstreamChannelFreqDesc, prometheus.GaugeValue, parseFloat(c.Frequency), id)
+        ch <- prometheus.MustNewConstMetric(upstreamChannelLockDesc, prometheus.GaugeValue, statusToFloat(c.LockStatus), id)
+    }
+    return nil
+}
@@ -0,0 +1,36 @@
+package probe
+
+import (
+    "strconv"
+    "strings"
+)
+
+// parseFloat safely converts a string to float64.  Empty or invalid strings
+// evaluate to 0.0.
+func parseFloat(s string) float64 {
+    if s == "" {
+        return 0.0
+    }
+    // Remove any non‑numeric suffixes (e.g. dBmV, Hz) if present
+    s = strings.TrimSpace(s)
+    s = strings.TrimSuffix(s, "dBmV")
+    s = strings.TrimSuffix(s, "dB")
+    s = strings.TrimSuffix(s, "Hz")
+    f, err := strconv.ParseFloat(s, 64)
+    if err != nil {
+        return 0.0
+    }
+    return f
+}
+
+// statusToFloat converts lock/status strings to 1 or 0.  Recognises
+// "locked", "1", "on" as true.
+func statusToFloat(s string) float64 {
+    s = strings.TrimSpace(strings.ToLower(s))
+    switch s {
+    case "1", "true", "locked", "yes", "on":
+        return 1.0
+    default:
+        return 0.0
+    }
+}